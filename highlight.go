@@ -0,0 +1,117 @@
+// Lute - A structured markdown engine.
+// Copyright (C) 2019-present, b3log.org
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lute
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+// CodeHighlighter 是代码高亮渲染钩子。渲染 NodeCodeBlock（以及 NodeCodeSpan）时，
+// 如果 options.CodeHighlighter 不为 nil 就优先调用它生成高亮后的 HTML，由渲染器原样输出在
+// <code> 内容的位置；ok 为 false 或者未设置该钩子时，回退到既有的 HTML 转义输出路径。
+// 该接口不绑定任何具体的高亮实现，宿主可以自行适配 Chroma 等分词器后通过 options.CodeHighlighter 接入。
+// options.HighlightStyle 在没有可用 CodeHighlighter（或其拒绝处理）时，由兜底转义路径
+// 直接作为 <pre><code> 上的 class 属性值使用，供宿主配合自己的 CSS 方案着色；
+// options.HighlightLineNumbers 同样由兜底转义路径直接使用，在没有可用高亮结果时也能看到行号。
+//
+// 注意：NodeCodeBlock 的 info string 在块级解析阶段就已经拆出语言标识，这里的 lang 参数即取自那里；
+// 真正调用 Highlight 发生在渲染阶段。
+type CodeHighlighter interface {
+	Highlight(source, lang string) (html string, ok bool)
+}
+
+// codeInfoStringer 是围栏代码块用来暴露其 info string 语言标识的可选接口。块级解析阶段产出的
+// NodeCodeBlock 具体类型需要实现它才能被 renderCodeBlock 取到 lang；该类型和它的 Finalize 逻辑
+// 属于块级解析阶段（不在这次改动涉及的文件范围内），这里按接口断言接入，对方未实现时 lang 为空串、
+// 不影响兜底转义路径。
+type codeInfoStringer interface {
+	Info() string
+}
+
+// renderCodeBlock 渲染围栏代码块：若设置了 CodeHighlighter 且 Highlight 返回 ok，直接原样输出其 HTML；
+// 否则回退到转义后的 <pre><code>，按 HighlightStyle 加上样式类名，并在 HighlightLineNumbers 开启时加上行号。
+func (r *HtmlRenderer) renderCodeBlock(node Node, entering bool) (WalkStatus, error) {
+	if !entering {
+		return WalkContinue, nil
+	}
+
+	source := node.Tokens().string()
+	lang := ""
+	if cis, ok := node.(codeInfoStringer); ok {
+		lang = cis.Info()
+	}
+
+	if nil != r.option.CodeHighlighter {
+		if highlighted, ok := r.option.CodeHighlighter.Highlight(source, lang); ok {
+			r.Writer.WriteString(highlighted)
+			return WalkSkipChildren, nil
+		}
+	}
+
+	r.Writer.WriteString("<pre><code")
+	if "" != r.option.HighlightStyle {
+		r.Writer.WriteString(` class="`)
+		r.Writer.WriteString(html.EscapeString(r.option.HighlightStyle))
+		r.Writer.WriteString(`"`)
+	}
+	r.Writer.WriteString(">")
+	r.Writer.WriteString(escapeCodeFallback(source, r.option.HighlightLineNumbers))
+	r.Writer.WriteString("</code></pre>\n")
+	return WalkSkipChildren, nil
+}
+
+// renderCodeSpan 渲染行内代码。同样优先尝试 CodeHighlighter（lang 为空串），回退到转义后的 <code>。
+func (r *HtmlRenderer) renderCodeSpan(node Node, entering bool) (WalkStatus, error) {
+	if !entering {
+		return WalkContinue, nil
+	}
+
+	source := node.Tokens().string()
+	if nil != r.option.CodeHighlighter {
+		if highlighted, ok := r.option.CodeHighlighter.Highlight(source, ""); ok {
+			r.Writer.WriteString("<code>")
+			r.Writer.WriteString(highlighted)
+			r.Writer.WriteString("</code>")
+			return WalkSkipChildren, nil
+		}
+	}
+
+	r.Writer.WriteString("<code>")
+	r.Writer.WriteString(html.EscapeString(source))
+	r.Writer.WriteString("</code>")
+	return WalkSkipChildren, nil
+}
+
+// escapeCodeFallback 是没有可用高亮结果时的兜底输出：对源码做 HTML 转义，
+// lineNumbers 为 true 时为每一行加上行号前缀。
+func escapeCodeFallback(source string, lineNumbers bool) string {
+	escaped := html.EscapeString(source)
+	if !lineNumbers {
+		return escaped
+	}
+
+	lines := strings.Split(strings.TrimSuffix(escaped, "\n"), "\n")
+	var b strings.Builder
+	for i, line := range lines {
+		b.WriteString(strconv.Itoa(i + 1))
+		b.WriteByte('\t')
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,72 @@
+// Lute - A structured markdown engine.
+// Copyright (C) 2019-present, b3log.org
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lute
+
+import "testing"
+
+func TestEscapeCodeFallback(t *testing.T) {
+	cases := []struct {
+		name        string
+		source      string
+		lineNumbers bool
+		want        string
+	}{
+		{"escapes html, no line numbers", "<b>hi</b>\n", false, "&lt;b&gt;hi&lt;/b&gt;\n"},
+		{"adds line numbers", "a\nb\n", true, "1\ta\n2\tb\n"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := escapeCodeFallback(c.source, c.lineNumbers); got != c.want {
+				t.Errorf("escapeCodeFallback(%q, %v) = %q, want %q", c.source, c.lineNumbers, got, c.want)
+			}
+		})
+	}
+}
+
+type stubCodeHighlighter struct {
+	html string
+	ok   bool
+}
+
+func (s stubCodeHighlighter) Highlight(source, lang string) (string, bool) {
+	return s.html, s.ok
+}
+
+func TestRenderCodeBlockUsesHighlighterWhenOk(t *testing.T) {
+	r := &HtmlRenderer{option: options{CodeHighlighter: stubCodeHighlighter{html: "<pre>HI</pre>", ok: true}}}
+	node := &Text{tokens: toItems("package main")}
+
+	if _, err := r.renderCodeBlock(node, true); nil != err {
+		t.Fatalf("renderCodeBlock returned error: %v", err)
+	}
+	if "<pre>HI</pre>" != r.Writer.String() {
+		t.Errorf("output = %q, want highlighter output verbatim", r.Writer.String())
+	}
+}
+
+func TestRenderCodeBlockFallsBackWhenHighlighterDeclines(t *testing.T) {
+	r := &HtmlRenderer{option: options{CodeHighlighter: stubCodeHighlighter{ok: false}}}
+	node := &Text{tokens: toItems("<tag>")}
+
+	if _, err := r.renderCodeBlock(node, true); nil != err {
+		t.Fatalf("renderCodeBlock returned error: %v", err)
+	}
+	want := "<pre><code>&lt;tag&gt;</code></pre>\n"
+	if want != r.Writer.String() {
+		t.Errorf("output = %q, want %q", r.Writer.String(), want)
+	}
+}
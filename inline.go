@@ -67,6 +67,11 @@ func (t *Tree) parseInlines() {
 				child = next
 			}
 
+			if t.context.option.TaskListItems && NodeParagraph == typ {
+				// 识别列表项第一个段落开头的任务列表复选框（[ ]、[x]、[X]）
+				t.parseTaskListItemMarker(n)
+			}
+
 			if t.context.option.GFMAutoLink {
 				// 处理 GFM 自动邮件链接
 				for child := n.FirstChild(); nil != child; child = child.Next() {
@@ -75,6 +80,11 @@ func (t *Tree) parseInlines() {
 					}
 				}
 			}
+
+			if t.context.option.SmartyPants {
+				// 处理智能排版替换（连字符、省略号、弯引号等）
+				t.parseSmartyPants(n)
+			}
 		}
 
 		return WalkContinue, nil
@@ -118,6 +128,8 @@ func (t *Tree) parseInline(block Node) bool {
 			n = t.parseEntity(tokens)
 		case itemBang:
 			n = t.parseBang(tokens)
+		case itemDollar:
+			n = t.parseInlineMath(tokens)
 		default:
 			if t.context.option.GFMAutoLink {
 				n = t.parseGfmAutoLink(tokens, "www.")
@@ -302,6 +314,8 @@ func (t *Tree) parseCloseBracket(tokens items) Node {
 	}
 
 	if matched {
+		dest = t.resolveDest(dest, isImage)
+
 		var node Node
 		if isImage {
 			node = &Image{&BaseNode{typ: NodeImage}, dest, title}
@@ -346,6 +360,13 @@ func (t *Tree) parseCloseBracket(tokens items) Node {
 }
 
 func (t *Tree) parseOpenBracket(tokens items) (ret Node) {
+	if t.context.option.WikiLink && t.context.pos+1 < len(tokens) && itemOpenBracket == tokens[t.context.pos+1] {
+		if n := t.parseWikiLink(tokens); nil != n {
+			return n
+		}
+		// 未匹配到 ]]（或中途出现了嵌套的 [），回退到普通方括号处理
+	}
+
 	t.context.pos++
 	ret = &Text{tokens: toItems("[")}
 	// Add entry to stack for this opener
@@ -414,7 +435,8 @@ func (t *Tree) parseText(tokens items) (ret Node) {
 func (t *Tree) isMarker(token byte) bool {
 	return itemAsterisk == token || itemUnderscore == token || itemOpenBracket == token || itemBang == token ||
 		itemNewline == token || itemBackslash == token || itemBacktick == token ||
-		itemLess == token || itemCloseBracket == token || itemAmpersand == token || itemTilde == token
+		itemLess == token || itemCloseBracket == token || itemAmpersand == token || itemTilde == token ||
+		itemDollar == token
 }
 
 func (t *Tree) parseNewline(block Node, tokens items) (ret Node) {
@@ -0,0 +1,59 @@
+// Lute - A structured markdown engine.
+// Copyright (C) 2019-present, b3log.org
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lute
+
+import (
+	"net/url"
+	"strings"
+)
+
+// resolveDest 依次应用 LinkBase/ImageBase 的相对地址拼接和 LinkResolver 自定义钩子，
+// 在 parseCloseBracket 判定出链接或图片的最终 dest 之后、构造节点之前调用。
+// 两种机制可以组合使用：先做 base 拼接，再交给 LinkResolver 做进一步改写。
+func (t *Tree) resolveDest(dest string, isImage bool) string {
+	base := t.context.option.LinkBase
+	if isImage {
+		base = t.context.option.ImageBase
+	}
+
+	if "" != base && isRelativeDest(dest) {
+		if baseURL, err := url.Parse(base); nil == err {
+			if ref, err := url.Parse(dest); nil == err {
+				dest = baseURL.ResolveReference(ref).String()
+			}
+		}
+	}
+
+	if nil != t.context.option.LinkResolver {
+		dest = t.context.option.LinkResolver(dest, isImage)
+	}
+
+	return dest
+}
+
+// isRelativeDest 判断 dest 是否是一个需要拼接 base 的相对地址：没有 scheme，
+// 并且不是以 /、# 或 mailto: 开头。
+func isRelativeDest(dest string) bool {
+	if "" == dest || strings.HasPrefix(dest, "#") || strings.HasPrefix(dest, "/") || strings.HasPrefix(dest, "mailto:") {
+		return false
+	}
+
+	u, err := url.Parse(dest)
+	if nil != err {
+		return false
+	}
+	return "" == u.Scheme
+}
@@ -0,0 +1,69 @@
+// Lute - A structured markdown engine.
+// Copyright (C) 2019-present, b3log.org
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lute
+
+import "testing"
+
+func TestIsRelativeDest(t *testing.T) {
+	cases := []struct {
+		dest string
+		want bool
+	}{
+		{"foo.md", true},
+		{"./foo/bar.md", true},
+		{"/foo.md", false},
+		{"#section", false},
+		{"mailto:a@b.com", false},
+		{"https://example.com/foo", false},
+		{"", false},
+	}
+
+	for _, c := range cases {
+		if got := isRelativeDest(c.dest); got != c.want {
+			t.Errorf("isRelativeDest(%q) = %v, want %v", c.dest, got, c.want)
+		}
+	}
+}
+
+func TestResolveDest(t *testing.T) {
+	tree := &Tree{}
+	tree.context.option.LinkBase = "https://example.com/wiki/"
+	tree.context.option.ImageBase = "https://static.example.com/img/"
+
+	if got := tree.resolveDest("foo.md", false); "https://example.com/wiki/foo.md" != got {
+		t.Errorf("link resolveDest = %q, want https://example.com/wiki/foo.md", got)
+	}
+	if got := tree.resolveDest("foo.png", true); "https://static.example.com/img/foo.png" != got {
+		t.Errorf("image resolveDest = %q, want https://static.example.com/img/foo.png", got)
+	}
+	if got := tree.resolveDest("https://other.com/x", false); "https://other.com/x" != got {
+		t.Errorf("absolute dest should pass through unchanged, got %q", got)
+	}
+}
+
+func TestResolveDestLinkResolverComposesAfterBase(t *testing.T) {
+	tree := &Tree{}
+	tree.context.option.LinkBase = "https://example.com/wiki/"
+	tree.context.option.LinkResolver = func(dest string, isImage bool) string {
+		return dest + "?from=resolver"
+	}
+
+	got := tree.resolveDest("foo.md", false)
+	want := "https://example.com/wiki/foo.md?from=resolver"
+	if got != want {
+		t.Errorf("resolveDest = %q, want %q", got, want)
+	}
+}
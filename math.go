@@ -0,0 +1,131 @@
+// Lute - A structured markdown engine.
+// Copyright (C) 2019-present, b3log.org
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lute
+
+import (
+	"html"
+	"strings"
+)
+
+// InlineMath 是行内数学公式节点，对应 $...$。
+type InlineMath struct {
+	*BaseNode
+	Content string
+}
+
+// MathBlock 是同一行内成对 $$...$$ 包裹的块级数学公式节点。
+type MathBlock struct {
+	*BaseNode
+	Content string
+}
+
+// parseInlineMath 解析以 $ 开头的数学公式。单个 $ 包裹的是行内公式（NodeInlineMath），
+// 同一行内紧跟的 $$ 包裹的是块级公式（NodeMathBlock）。
+// 开始定界符左侧要求非数字（避免匹配 $5 这样的价格）、右侧要求非空白，结束定界符左侧要求非空白、
+// 右侧要求非数字，规则参考 Pandoc。
+// 公式内容原样保留、不做进一步行级解析（类似 parseCodeSpan），转义的 \$ 在内容中保留为字面量 $。
+func (t *Tree) parseInlineMath(tokens items) (ret Node) {
+	start := t.context.pos
+	if !t.context.option.Math || (0 < start && isDigit(tokens[start-1])) {
+		t.context.pos++
+		return &Text{tokens: toItems("$")}
+	}
+
+	length := len(tokens)
+	display := start+1 < length && itemDollar == tokens[start+1]
+	contentStart := start + 1
+	if display {
+		contentStart++
+	}
+
+	i := contentStart
+	for ; i < length; i++ {
+		token := tokens[i]
+		if itemBackslash == token && i+1 < length && itemDollar == tokens[i+1] {
+			i++
+			continue
+		}
+		if itemNewline == token {
+			break
+		}
+		if itemDollar == token {
+			if !display {
+				break
+			}
+			if i+1 < length && itemDollar == tokens[i+1] {
+				break
+			}
+		}
+	}
+
+	closeLen := 1
+	if display {
+		closeLen = 2
+	}
+	if i+closeLen > length || itemDollar != tokens[i] || (display && itemDollar != tokens[i+1]) {
+		// 未找到匹配的结束定界符或公式跨行，回退为字面量 $
+		t.context.pos++
+		return &Text{tokens: toItems("$")}
+	}
+
+	if i == contentStart || isWhitespace(tokens[contentStart]) || isWhitespace(tokens[i-1]) {
+		// 内容为空或两端有空白，不满足定界符贴近要求
+		t.context.pos++
+		return &Text{tokens: toItems("$")}
+	}
+
+	closeEnd := i + closeLen
+	if closeEnd < length && isDigit(tokens[closeEnd]) {
+		// 结束定界符右侧紧跟数字，按 Pandoc 规则视为价格/价格区间的一部分（如 $5-$10），不是公式
+		t.context.pos++
+		return &Text{tokens: toItems("$")}
+	}
+
+	content := strings.ReplaceAll(tokens[contentStart:i].string(), "\\$", "$")
+	t.context.pos = i + closeLen
+
+	if display {
+		return &MathBlock{&BaseNode{typ: NodeMathBlock}, content}
+	}
+	return &InlineMath{&BaseNode{typ: NodeInlineMath}, content}
+}
+
+// renderInlineMath 将 NodeInlineMath 渲染为 <span class="math inline">…</span>，内容原样转义输出，
+// 交由宿主接入的 KaTeX/MathJax 等前端库按该 class 查找并渲染公式。
+func (r *HtmlRenderer) renderInlineMath(node Node, entering bool) (WalkStatus, error) {
+	if !entering {
+		return WalkContinue, nil
+	}
+
+	n := node.(*InlineMath)
+	r.Writer.WriteString(`<span class="math inline">`)
+	r.Writer.WriteString(html.EscapeString(n.Content))
+	r.Writer.WriteString(`</span>`)
+	return WalkSkipChildren, nil
+}
+
+// renderMathBlock 将 NodeMathBlock 渲染为 <span class="math display">…</span>，规则同 renderInlineMath。
+func (r *HtmlRenderer) renderMathBlock(node Node, entering bool) (WalkStatus, error) {
+	if !entering {
+		return WalkContinue, nil
+	}
+
+	n := node.(*MathBlock)
+	r.Writer.WriteString(`<span class="math display">`)
+	r.Writer.WriteString(html.EscapeString(n.Content))
+	r.Writer.WriteString(`</span>`)
+	return WalkSkipChildren, nil
+}
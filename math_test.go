@@ -0,0 +1,88 @@
+// Lute - A structured markdown engine.
+// Copyright (C) 2019-present, b3log.org
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lute
+
+import "testing"
+
+func TestParseInlineMath(t *testing.T) {
+	cases := []struct {
+		name        string
+		in          string
+		wantMath    bool
+		wantDisplay bool
+		wantContent string
+	}{
+		{"simple inline", "$x+y$", true, false, "x+y"},
+		{"display on one line", "$$x+y$$", true, true, "x+y"},
+		{"escaped dollar preserved", `$a\$b$`, true, false, "a$b"},
+		{"price not math", "$5-$10", false, false, ""},
+		{"no digit left of opener ok", "($x$)", true, false, "x"},
+		{"digit left of opener rejected", "5$x$", false, false, ""},
+		{"whitespace adjacent to delimiter rejected", "$ x$", false, false, ""},
+		{"unterminated falls back to literal", "$not closed", false, false, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tree := &Tree{}
+			tree.context.option.Math = true
+			tokens := toItems(c.in)
+
+			start := 0
+			for i, tok := range tokens {
+				if itemDollar == tok {
+					start = i
+					break
+				}
+			}
+			tree.context.pos = start
+
+			node := tree.parseInlineMath(tokens)
+			switch n := node.(type) {
+			case *InlineMath:
+				if !c.wantMath || c.wantDisplay {
+					t.Fatalf("parseInlineMath(%q) = InlineMath{%q}, want no match or display match", c.in, n.Content)
+				}
+				if n.Content != c.wantContent {
+					t.Errorf("content = %q, want %q", n.Content, c.wantContent)
+				}
+			case *MathBlock:
+				if !c.wantMath || !c.wantDisplay {
+					t.Fatalf("parseInlineMath(%q) = MathBlock{%q}, want no match or inline match", c.in, n.Content)
+				}
+				if n.Content != c.wantContent {
+					t.Errorf("content = %q, want %q", n.Content, c.wantContent)
+				}
+			default:
+				if c.wantMath {
+					t.Fatalf("parseInlineMath(%q) = %#v, want a math node", c.in, node)
+				}
+			}
+		})
+	}
+}
+
+func TestParseInlineMathDisabledByOption(t *testing.T) {
+	tree := &Tree{}
+	tree.context.option.Math = false
+	tokens := toItems("$x+y$")
+	tree.context.pos = 0
+
+	node := tree.parseInlineMath(tokens)
+	if _, ok := node.(*Text); !ok {
+		t.Fatalf("parseInlineMath with Math disabled = %#v, want literal Text", node)
+	}
+}
@@ -0,0 +1,31 @@
+// Lute - A structured markdown engine.
+// Copyright (C) 2019-present, b3log.org
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lute
+
+// newNodeType 是本系列新增节点类型的起始取值，刻意和核心的 NodeType 枚举（不在本文件中）错开，
+// 避免两边的 const 块重新对齐时撞到同一个数值。
+const newNodeType = 1 << 20
+
+const (
+	// NodeInlineMath 是单个 $ 包裹的行内数学公式节点。
+	NodeInlineMath NodeType = newNodeType + iota
+	// NodeMathBlock 是同一行内成对 $$ 包裹的块级数学公式节点。
+	NodeMathBlock
+	// NodeWikiLink 是 [[target|label]] 形式的维基链接节点。
+	NodeWikiLink
+	// NodeTaskListItemMarker 是 GFM 任务列表复选框节点。
+	NodeTaskListItemMarker
+)
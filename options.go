@@ -0,0 +1,53 @@
+// Lute - A structured markdown engine.
+// Copyright (C) 2019-present, b3log.org
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lute
+
+// options 承载解析/渲染过程中用到的开关和钩子，t.context.option 即此类型。
+type options struct {
+	// SmartyPants 开启后会在 parseInlines 里对每个块节点跑一遍排版替换（连字符、省略号、弯引号）。
+	SmartyPants bool
+
+	// Math 开启后 parseInline 遇到 $ 时会尝试解析行内/块级数学公式，关闭时 $ 按普通字符处理。
+	Math bool
+
+	// WikiLink 开启后 parseOpenBracket 遇到 [[ 时会尝试按 parseWikiLink 解析维基链接。
+	WikiLink bool
+
+	// WikiLinkResolver 将 parseWikiLink 解出的 target 解析为实际链接地址，ok 为 false 表示目标页面不存在。
+	// 为 nil 时维基链接的 Dest 直接取 target 本身，Exists 恒为 true。
+	WikiLinkResolver func(target string) (dest string, ok bool)
+
+	// TaskListItems 开启后会在列表项首段开头识别 [ ]/[x]/[X] 任务列表复选框标记。
+	TaskListItems bool
+
+	// CodeHighlighter 不为 nil 时，renderCodeBlock/renderCodeSpan 优先调用它生成高亮后的 HTML，
+	// 由渲染器原样输出；ok 为 false 或未设置该钩子时回退到转义输出。
+	CodeHighlighter CodeHighlighter
+
+	// HighlightLineNumbers 控制围栏代码块未命中 CodeHighlighter 时的兜底转义输出是否带上行号前缀（行内代码不加行号）。
+	HighlightLineNumbers bool
+
+	// HighlightStyle 是围栏代码块没有可用 CodeHighlighter 时，兜底转义输出的 <code> 标签上附带的样式类名，
+	// 留给宿主自己定义配色方案（如 "github"、"monokai"），为空则不附加 class 属性。
+	HighlightStyle string
+
+	// LinkBase/ImageBase 分别用于拼接相对链接地址和相对图片地址，resolveDest 在 LinkResolver 之前应用。
+	LinkBase  string
+	ImageBase string
+
+	// LinkResolver 在 LinkBase/ImageBase 拼接之后对 dest 做进一步改写，isImage 区分调用方是链接还是图片。
+	LinkResolver func(dest string, isImage bool) string
+}
@@ -0,0 +1,97 @@
+// Lute - A structured markdown engine.
+// Copyright (C) 2019-present, b3log.org
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lute
+
+import (
+	"strings"
+	"unicode"
+)
+
+// smartyPantsDashesEllipsis 是连字符与省略号的替换表，顺序保证 --- 先于 -- 匹配。
+var smartyPantsDashesEllipsis = []struct {
+	from string
+	to   string
+}{
+	{"---", "—"}, // em dash
+	{"--", "–"},  // en dash
+	{"...", "…"}, // horizontal ellipsis
+}
+
+// parseSmartyPants 对块节点 block 下所有层级的 NodeText 子节点做一遍排版替换：
+// -- 替换为 en dash，--- 替换为 em dash，... 替换为省略号，' 和 " 根据上下文替换为对应方向的弯引号。
+// 使用 Walk 递归遍历而不是只看直接子节点，这样嵌套在 Emphasis/Strong/Strikethrough 里的文本
+// （比如 *isn't* 或 **don't**）也能被处理到；代码段、自动链接和行内 HTML 本身不会生成 NodeText，
+// 所以天然不会被误伤。引号方向状态在每个块节点开始时重置，不会跨块节点保留。
+func (t *Tree) parseSmartyPants(block Node) {
+	quotes := &smartyPantsQuoteState{}
+	Walk(block, func(n Node, entering bool) (WalkStatus, error) {
+		if !entering || NodeText != n.Type() {
+			return WalkContinue, nil
+		}
+
+		text, ok := n.(*Text)
+		if !ok {
+			return WalkContinue, nil
+		}
+
+		text.tokens = toItems(quotes.replace(text.tokens.string()))
+		return WalkContinue, nil
+	})
+}
+
+// smartyPantsQuoteState 跟踪上一个已处理字符，用于判断 ' 和 " 应当替换为开引号还是闭引号。
+type smartyPantsQuoteState struct {
+	prevRune rune
+	prevSet  bool
+}
+
+// replace 先做连字符、省略号的字面量替换，再逐字符处理弯引号。
+func (quotes *smartyPantsQuoteState) replace(s string) string {
+	for _, rule := range smartyPantsDashesEllipsis {
+		s = strings.ReplaceAll(s, rule.from, rule.to)
+	}
+
+	var b strings.Builder
+	for _, r := range []rune(s) {
+		switch r {
+		case '\'':
+			if quotes.opening() {
+				b.WriteRune('‘')
+			} else {
+				b.WriteRune('’') // 单词内部的撇号（如 don't）始终使用右单引号
+			}
+		case '"':
+			if quotes.opening() {
+				b.WriteRune('“')
+			} else {
+				b.WriteRune('”')
+			}
+		default:
+			b.WriteRune(r)
+		}
+		quotes.prevRune = r
+		quotes.prevSet = true
+	}
+	return b.String()
+}
+
+// opening 前一个字符是空白或标点（或没有前一个字符）时判定为开引号，是字母或数字时判定为闭引号。
+func (quotes *smartyPantsQuoteState) opening() bool {
+	if !quotes.prevSet {
+		return true
+	}
+	return !unicode.IsLetter(quotes.prevRune) && !unicode.IsDigit(quotes.prevRune)
+}
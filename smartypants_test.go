@@ -0,0 +1,50 @@
+// Lute - A structured markdown engine.
+// Copyright (C) 2019-present, b3log.org
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lute
+
+import "testing"
+
+func TestSmartyPantsReplace(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{"em dash before en dash", "foo---bar--baz", "foo—bar–baz"},
+		{"ellipsis", "wait...", "wait…"},
+		{"opening quote after space", " \"hi\"", " “hi”"},
+		{"closing quote inside word", "don't", "don’t"},
+		{"opening single quote at start", "'tis the season", "‘tis the season"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			quotes := &smartyPantsQuoteState{}
+			if got := quotes.replace(c.in); got != c.out {
+				t.Errorf("replace(%q) = %q, want %q", c.in, got, c.out)
+			}
+		})
+	}
+}
+
+func TestSmartyPantsQuoteStateNotSharedBetweenInstances(t *testing.T) {
+	first := &smartyPantsQuoteState{}
+	first.replace("word'")
+	second := &smartyPantsQuoteState{}
+	if got := second.replace("'word"); got != "‘word" {
+		t.Errorf("fresh state should treat leading ' as opening, got %q", got)
+	}
+}
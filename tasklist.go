@@ -0,0 +1,109 @@
+// Lute - A structured markdown engine.
+// Copyright (C) 2019-present, b3log.org
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lute
+
+// TaskListItemMarker 是 GFM 任务列表复选框节点，Checked 表示是否已勾选（[x] 或 [X]）。
+type TaskListItemMarker struct {
+	*BaseNode
+	Checked bool
+}
+
+// parseTaskListItemMarker 检测段落 paragraph 的第一个子节点是否是 [ ]、[x] 或 [X] 开头的任务列表标记。
+// 只有当 paragraph 是其父节点（必须是 NodeListItem）的第一个子节点时才生效，避免误伤列表项内后续的段落。
+// 命中时用 NodeTaskListItemMarker 替换开头的方括号标记三（或四）个 token，并去掉紧跟其后的一个空格。
+func (t *Tree) parseTaskListItemMarker(paragraph Node) {
+	parent := paragraph.Parent()
+	if nil == parent || NodeListItem != parent.Type() || parent.FirstChild() != paragraph {
+		return
+	}
+
+	first := paragraph.FirstChild()
+	if nil == first {
+		return
+	}
+	text, ok := first.(*Text)
+	if !ok {
+		return
+	}
+
+	tokens := text.tokens
+	if 4 > len(tokens) || itemOpenBracket != tokens[0] || itemCloseBracket != tokens[2] || itemSpace != tokens[3] {
+		return
+	}
+
+	var checked bool
+	switch tokens[1] {
+	case itemSpace:
+		checked = false
+	case 'x', 'X':
+		checked = true
+	default:
+		return
+	}
+
+	remaining := tokens[4:]
+
+	// 摘下全部子节点后按新的顺序重新挂载，因为节点上没有提供任意位置插入的方法
+	var rest []Node
+	for child := paragraph.FirstChild(); nil != child; {
+		next := child.Next()
+		child.Unlink()
+		rest = append(rest, child)
+		child = next
+	}
+
+	paragraph.AppendChild(paragraph, &TaskListItemMarker{&BaseNode{typ: NodeTaskListItemMarker}, checked})
+	if 0 < len(remaining) {
+		text.tokens = remaining
+		paragraph.AppendChild(paragraph, text)
+	}
+	for _, child := range rest[1:] {
+		paragraph.AppendChild(paragraph, child)
+	}
+}
+
+// renderTaskListItemMarker 将 NodeTaskListItemMarker 渲染为一个禁用的 checkbox input，
+// Checked 为 true 时带上 checked 属性。
+func (r *HtmlRenderer) renderTaskListItemMarker(node Node, entering bool) (WalkStatus, error) {
+	if !entering {
+		return WalkContinue, nil
+	}
+
+	n := node.(*TaskListItemMarker)
+	r.Writer.WriteString(`<input type="checkbox" disabled`)
+	if n.Checked {
+		r.Writer.WriteString(` checked`)
+	}
+	r.Writer.WriteString(`>`)
+	return WalkSkipChildren, nil
+}
+
+// IsTaskListItem 判断列表项 li 是否是任务列表项：其第一个子节点是段落，且该段落的第一个子节点
+// 是 NodeTaskListItemMarker。列表项渲染器在输出 <li> 开始标签时应调用它来决定是否追加
+// task-list-item 样式类。
+func IsTaskListItem(li Node) bool {
+	if nil == li || NodeListItem != li.Type() {
+		return false
+	}
+
+	p := li.FirstChild()
+	if nil == p || NodeParagraph != p.Type() {
+		return false
+	}
+
+	marker := p.FirstChild()
+	return nil != marker && NodeTaskListItemMarker == marker.Type()
+}
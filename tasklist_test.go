@@ -0,0 +1,107 @@
+// Lute - A structured markdown engine.
+// Copyright (C) 2019-present, b3log.org
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lute
+
+import "testing"
+
+func newTaskListParagraph(text string) (li Node, paragraph Node) {
+	li = &BaseNode{typ: NodeListItem}
+	paragraph = &BaseNode{typ: NodeParagraph}
+	li.AppendChild(li, paragraph)
+	paragraph.AppendChild(paragraph, &Text{tokens: toItems(text)})
+	return li, paragraph
+}
+
+func TestParseTaskListItemMarker(t *testing.T) {
+	cases := []struct {
+		name        string
+		text        string
+		wantMarker  bool
+		wantChecked bool
+		wantRest    string
+	}{
+		{"unchecked", "[ ] todo", true, false, "todo"},
+		{"checked lowercase", "[x] done", true, true, "done"},
+		{"checked uppercase", "[X] done", true, true, "done"},
+		{"not a checkbox", "plain text", false, false, "plain text"},
+		{"checkbox with no content left", "[ ] ", true, false, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tree := &Tree{}
+			_, paragraph := newTaskListParagraph(c.text)
+
+			tree.parseTaskListItemMarker(paragraph)
+
+			marker, ok := paragraph.FirstChild().(*TaskListItemMarker)
+			if !c.wantMarker {
+				if ok {
+					t.Fatalf("did not expect a TaskListItemMarker for %q", c.text)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("expected a TaskListItemMarker for %q, got %#v", c.text, paragraph.FirstChild())
+			}
+			if marker.Checked != c.wantChecked {
+				t.Errorf("Checked = %v, want %v", marker.Checked, c.wantChecked)
+			}
+			rest := ""
+			if next, ok := marker.Next().(*Text); ok {
+				rest = next.tokens.string()
+			}
+			if rest != c.wantRest {
+				t.Errorf("text after marker = %q, want %q", rest, c.wantRest)
+			}
+		})
+	}
+}
+
+func TestParseTaskListItemMarkerOnlyFiresOnFirstParagraphChild(t *testing.T) {
+	li := &BaseNode{typ: NodeListItem}
+	firstParagraph := &BaseNode{typ: NodeParagraph}
+	secondParagraph := &BaseNode{typ: NodeParagraph}
+	li.AppendChild(li, firstParagraph)
+	li.AppendChild(li, secondParagraph)
+	firstParagraph.AppendChild(firstParagraph, &Text{tokens: toItems("intro")})
+	secondParagraph.AppendChild(secondParagraph, &Text{tokens: toItems("[x] nested, not an item start")})
+
+	tree := &Tree{}
+	tree.parseTaskListItemMarker(secondParagraph)
+
+	if _, ok := secondParagraph.FirstChild().(*TaskListItemMarker); ok {
+		t.Fatalf("a non-first paragraph in a list item should not get a task list marker")
+	}
+}
+
+func TestIsTaskListItem(t *testing.T) {
+	li, _ := newTaskListParagraph("[ ] todo")
+	tree := &Tree{}
+	tree.parseTaskListItemMarker(li.FirstChild())
+
+	if !IsTaskListItem(li) {
+		t.Errorf("IsTaskListItem = false, want true")
+	}
+
+	plainLi := &BaseNode{typ: NodeListItem}
+	plainParagraph := &BaseNode{typ: NodeParagraph}
+	plainLi.AppendChild(plainLi, plainParagraph)
+	plainParagraph.AppendChild(plainParagraph, &Text{tokens: toItems("just text")})
+	if IsTaskListItem(plainLi) {
+		t.Errorf("IsTaskListItem = true for a plain list item, want false")
+	}
+}
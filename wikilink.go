@@ -0,0 +1,103 @@
+// Lute - A structured markdown engine.
+// Copyright (C) 2019-present, b3log.org
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lute
+
+import (
+	"html"
+	"strings"
+)
+
+// WikiLink 是 [[target|label]] 形式的维基链接节点。
+// Target 是 | 之前的完整目标（包含 # 及锚点，原样交给 WikiLinkResolver 解析），Anchor 是 target 中 # 之后的部分，
+// Label 是 | 之后的自定义显示文本（未指定时为空，由渲染器回退为 Target）。
+type WikiLink struct {
+	*BaseNode
+	Target string
+	Label  string
+	Anchor string
+	Dest   string
+	Exists bool
+}
+
+// parseWikiLink 在遇到连续的 [[ 时尝试扫描到匹配的 ]]，按第一个 | 切分为 target 和可选的 label。
+// 扫描过程中一旦遇到嵌套的 [ 或跨行，立即放弃匹配，返回 nil 交由调用方回退到 parseOpenBracket 的常规处理；
+// 单个 ] 不会立即中止匹配，继续向后扫描直到找到 ]] 或者扫描到结尾，这与 CommonMark 扫描引用 label 时
+// 对单个 ] 的处理方式一致。
+func (t *Tree) parseWikiLink(tokens items) Node {
+	start := t.context.pos
+	length := len(tokens)
+	i := start + 2 // 跳过开头的 [[
+	for ; i < length; i++ {
+		switch tokens[i] {
+		case itemOpenBracket, itemNewline:
+			return nil
+		case itemCloseBracket:
+			if i+1 < length && itemCloseBracket == tokens[i+1] {
+				goto matched
+			}
+		}
+	}
+	return nil
+
+matched:
+	raw := tokens[start+2 : i].string()
+	target := raw
+	label := ""
+	if idx := strings.IndexByte(raw, '|'); 0 <= idx {
+		target = raw[:idx]
+		label = raw[idx+1:]
+	}
+
+	anchor := ""
+	if idx := strings.IndexByte(target, '#'); 0 <= idx {
+		anchor = target[idx+1:]
+	}
+
+	dest := target
+	exists := true
+	if nil != t.context.option.WikiLinkResolver {
+		dest, exists = t.context.option.WikiLinkResolver(target)
+	}
+
+	t.context.pos = i + 2
+	return &WikiLink{&BaseNode{typ: NodeWikiLink}, target, label, anchor, dest, exists}
+}
+
+// renderWikiLink 将 NodeWikiLink 渲染为 <a> 标签：href 取已经过 WikiLinkResolver 解析的 Dest，
+// 链接文本优先使用 Label，未指定 Label 时回退为 Target。解析结果标记为不存在的页面（Exists 为 false）
+// 会额外带上 new 样式类，供宿主用来标注"待创建"的维基页面。
+func (r *HtmlRenderer) renderWikiLink(node Node, entering bool) (WalkStatus, error) {
+	if !entering {
+		return WalkContinue, nil
+	}
+
+	n := node.(*WikiLink)
+	text := n.Label
+	if "" == text {
+		text = n.Target
+	}
+
+	r.Writer.WriteString(`<a href="`)
+	r.Writer.WriteString(html.EscapeString(n.Dest))
+	r.Writer.WriteString(`"`)
+	if !n.Exists {
+		r.Writer.WriteString(` class="new"`)
+	}
+	r.Writer.WriteString(`>`)
+	r.Writer.WriteString(html.EscapeString(text))
+	r.Writer.WriteString(`</a>`)
+	return WalkSkipChildren, nil
+}
@@ -0,0 +1,95 @@
+// Lute - A structured markdown engine.
+// Copyright (C) 2019-present, b3log.org
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lute
+
+import "testing"
+
+func TestParseWikiLink(t *testing.T) {
+	cases := []struct {
+		name       string
+		in         string
+		wantNil    bool
+		wantTarget string
+		wantLabel  string
+		wantAnchor string
+	}{
+		{"target only", "[[Foo Bar]]", false, "Foo Bar", "", ""},
+		{"target with label", "[[Foo Bar|Label]]", false, "Foo Bar", "Label", ""},
+		{"target with anchor", "[[Foo#Section|Jump]]", false, "Foo#Section", "Jump", "Section"},
+		{"nested bracket aborts match", "[[Foo [Bar]]]", true, "", "", ""},
+		{"missing closing aborts match", "[[Foo Bar", true, "", "", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tree := &Tree{}
+			tree.context.pos = 0
+			tokens := toItems(c.in)
+
+			node := tree.parseWikiLink(tokens)
+			if c.wantNil {
+				if nil != node {
+					t.Fatalf("parseWikiLink(%q) = %#v, want nil", c.in, node)
+				}
+				return
+			}
+
+			wl, ok := node.(*WikiLink)
+			if !ok {
+				t.Fatalf("parseWikiLink(%q) = %#v, want *WikiLink", c.in, node)
+			}
+			if wl.Target != c.wantTarget {
+				t.Errorf("Target = %q, want %q", wl.Target, c.wantTarget)
+			}
+			if wl.Label != c.wantLabel {
+				t.Errorf("Label = %q, want %q", wl.Label, c.wantLabel)
+			}
+			if wl.Anchor != c.wantAnchor {
+				t.Errorf("Anchor = %q, want %q", wl.Anchor, c.wantAnchor)
+			}
+		})
+	}
+}
+
+func TestParseWikiLinkResolver(t *testing.T) {
+	tree := &Tree{}
+	tree.context.pos = 0
+	tree.context.option.WikiLinkResolver = func(target string) (string, bool) {
+		if "Known Page" == target {
+			return "/wiki/known-page", true
+		}
+		return "/wiki/missing", false
+	}
+
+	node := tree.parseWikiLink(toItems("[[Known Page]]"))
+	wl, ok := node.(*WikiLink)
+	if !ok {
+		t.Fatalf("parseWikiLink = %#v, want *WikiLink", node)
+	}
+	if "/wiki/known-page" != wl.Dest || !wl.Exists {
+		t.Errorf("got Dest=%q Exists=%v, want /wiki/known-page, true", wl.Dest, wl.Exists)
+	}
+
+	tree.context.pos = 0
+	node = tree.parseWikiLink(toItems("[[Unknown Page]]"))
+	wl, ok = node.(*WikiLink)
+	if !ok {
+		t.Fatalf("parseWikiLink = %#v, want *WikiLink", node)
+	}
+	if wl.Exists {
+		t.Errorf("unresolved page should have Exists=false, got true")
+	}
+}